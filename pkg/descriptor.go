@@ -0,0 +1,24 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// deepCopyDescriptor returns a deep copy of d by round-tripping it through
+// JSON, so callers can't mutate a Package's or Resource's internal state
+// through a returned descriptor.
+func deepCopyDescriptor(d map[string]interface{}) (map[string]interface{}, error) {
+	if d == nil {
+		return nil, nil
+	}
+	buf, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed copying descriptor: %w", err)
+	}
+	var cp map[string]interface{}
+	if err := json.Unmarshal(buf, &cp); err != nil {
+		return nil, fmt.Errorf("failed copying descriptor: %w", err)
+	}
+	return cp, nil
+}
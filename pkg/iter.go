@@ -0,0 +1,295 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/OneCloudInc/datapackage-go/pkg/tableschema"
+)
+
+// IterOption configures a call to Iter, ReadAll, or Cast.
+type IterOption func(*iterConfig)
+
+type iterConfig struct {
+	client       *http.Client
+	timeout      time.Duration
+	maxRedirects int
+	maxBytes     int64
+}
+
+// WithIterHTTPClient overrides the http.Client used to fetch a remote
+// resource's data. The client is copied before use, so its CheckRedirect is
+// not mutated.
+func WithIterHTTPClient(c *http.Client) IterOption {
+	return func(cfg *iterConfig) { cfg.client = c }
+}
+
+// WithIterTimeout bounds how long Iter waits for a remote resource fetch.
+func WithIterTimeout(d time.Duration) IterOption {
+	return func(cfg *iterConfig) { cfg.timeout = d }
+}
+
+// WithIterMaxRedirects bounds how many redirects Iter follows when fetching
+// a remote resource.
+func WithIterMaxRedirects(n int) IterOption {
+	return func(cfg *iterConfig) { cfg.maxRedirects = n }
+}
+
+// WithIterMaxBytes bounds the size of a remote resource's fetched data.
+func WithIterMaxBytes(n int64) IterOption {
+	return func(cfg *iterConfig) { cfg.maxBytes = n }
+}
+
+// Iter opens the Resource's tabular data (inline "data", a local "path", or
+// a remote URL) and returns a RowIterator that casts each row according to
+// the resource's "schema" and "dialect" properties. Callers must Close the
+// returned iterator.
+func (r *Resource) Iter(opts ...IterOption) (*tableschema.RowIterator, error) {
+	cfg := iterConfig{
+		client:       http.DefaultClient,
+		timeout:      defaultTimeout,
+		maxRedirects: defaultMaxRedirects,
+		maxBytes:     defaultMaxBytes,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	schema, err := r.tableSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := r.open(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	it, err := tableschema.NewRowIterator(src, schema, r.dialect())
+	if err != nil {
+		if closer, ok := src.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	return it, nil
+}
+
+// ReadAll reads every row of the Resource's tabular data into memory.
+func (r *Resource) ReadAll(opts ...IterOption) ([][]interface{}, error) {
+	it, err := r.Iter(opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var rows [][]interface{}
+	for {
+		row, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Cast reads every row of the Resource's tabular data into out, which must
+// be a pointer to a slice of struct. Struct fields are matched to schema
+// fields by a `tableschema:"<name>"` tag, falling back to a case-insensitive
+// field name match.
+func (r *Resource) Cast(out interface{}, opts ...IterOption) error {
+	ptr := reflect.ValueOf(out)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("tableschema: Cast requires a pointer to a slice, got %T", out)
+	}
+	slice := ptr.Elem()
+	elemType := slice.Type().Elem()
+
+	it, err := r.Iter(opts...)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	names := it.Header()
+	for {
+		row, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		elem := reflect.New(elemType).Elem()
+		for i, v := range row {
+			if i >= len(names) {
+				break
+			}
+			fieldIdx := fieldIndexByTag(elemType, names[i])
+			if fieldIdx < 0 {
+				continue
+			}
+			field := elem.Field(fieldIdx)
+			rv := reflect.ValueOf(v)
+			if rv.IsValid() && rv.Type().AssignableTo(field.Type()) {
+				field.Set(rv)
+			}
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return nil
+}
+
+func fieldIndexByTag(t reflect.Type, name string) int {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("tableschema")
+		if tag == name || (tag == "" && strings.EqualFold(f.Name, name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Resource) tableSchema() (tableschema.Schema, error) {
+	raw, ok := r.descriptor["schema"].(map[string]interface{})
+	if !ok {
+		return tableschema.Schema{}, fmt.Errorf("resource %q has no tabular \"schema\"", r.Name)
+	}
+	return tableschema.FromDescriptor(raw)
+}
+
+func (r *Resource) dialect() tableschema.Dialect {
+	raw, ok := r.descriptor["dialect"].(map[string]interface{})
+	if !ok {
+		return tableschema.DefaultDialect
+	}
+	return tableschema.DialectFromDescriptor(raw)
+}
+
+// open returns a reader over the Resource's tabular data, from inline
+// "data", a local "path", or a remote URL, in that order of precedence. A
+// remote URL is fetched per cfg, bounding the request's timeout, redirects,
+// and response size the same way Load bounds a descriptor fetch.
+func (r *Resource) open(cfg *iterConfig) (io.Reader, error) {
+	if data, ok := r.descriptor["data"]; ok {
+		s, ok := data.(string)
+		if !ok {
+			return nil, fmt.Errorf("resource %q: inline non-string \"data\" is not supported by Iter", r.Name)
+		}
+		return strings.NewReader(s), nil
+	}
+
+	path := r.path()
+	if path == "" {
+		return nil, fmt.Errorf("resource %q has no readable \"data\" or \"path\"", r.Name)
+	}
+	if r.fsys != nil {
+		f, err := r.fsys.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("resource %q: failed opening %s in archive: %w", r.Name, path, err)
+		}
+		return f, nil
+	}
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		client := *cfg.client
+		client.Timeout = cfg.timeout
+		redirects := 0
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			redirects++
+			if redirects > cfg.maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", cfg.maxRedirects)
+			}
+			return nil
+		}
+		resp, err := client.Get(path)
+		if err != nil {
+			return nil, fmt.Errorf("resource %q: failed fetching %s: %w", r.Name, path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("resource %q: fetching %s: unexpected status %s", r.Name, path, resp.Status)
+		}
+		return &limitedReadCloser{r: resp.Body, limit: cfg.maxBytes}, nil
+	}
+
+	if err := safeLocalPath(path); err != nil {
+		return nil, fmt.Errorf("resource %q: %w", r.Name, err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("resource %q: failed opening %s: %w", r.Name, path, err)
+	}
+	return f, nil
+}
+
+// safeLocalPath rejects a resource "path" that escapes the directory the
+// descriptor lives in, so a descriptor fetched from an untrusted source
+// can't be used to read arbitrary files via an absolute path or a "../"
+// traversal. The embedded schemas in pkg/schemas don't encode this
+// constraint (unlike the upstream Frictionless Data Resource schema), so it
+// is enforced here instead.
+func safeLocalPath(path string) error {
+	if filepath.IsAbs(path) {
+		return fmt.Errorf("path %q must be relative, not absolute", path)
+	}
+	cleaned := filepath.ToSlash(filepath.Clean(path))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("path %q must not reference a parent directory", path)
+	}
+	return nil
+}
+
+// limitedReadCloser wraps a ReadCloser, failing once more than limit bytes
+// have been read instead of silently truncating the stream. Like
+// fetchDescriptor's use of io.LimitReader(r, maxBytes+1), it allows reading
+// one byte past limit before deciding the stream is oversized, so data that
+// is exactly limit bytes long is not mistaken for an overflow.
+type limitedReadCloser struct {
+	r     io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, fmt.Errorf("resource data exceeds maximum size of %d bytes", l.limit)
+	}
+	if max := l.limit + 1 - l.read; int64(len(p)) > max {
+		p = p[:max]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, fmt.Errorf("resource data exceeds maximum size of %d bytes", l.limit)
+	}
+	return n, err
+}
+
+func (l *limitedReadCloser) Close() error { return l.r.Close() }
+
+// path returns the resource's first declared "path", whether it is a single
+// string or an array of strings.
+func (r *Resource) path() string {
+	switch p := r.descriptor["path"].(type) {
+	case string:
+		return p
+	case []interface{}:
+		if len(p) == 0 {
+			return ""
+		}
+		s, _ := p[0].(string)
+		return s
+	default:
+		return ""
+	}
+}
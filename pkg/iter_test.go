@@ -0,0 +1,130 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestResource_ReadAll(t *testing.T) {
+	is := is.New(t)
+	r, err := NewUncheckedResource(map[string]interface{}{
+		"name": "res",
+		"data": "id,name\n1,Alice\n2,Bob\n",
+		"schema": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "id", "type": "integer"},
+				map[string]interface{}{"name": "name", "type": "string"},
+			},
+		},
+	})
+	is.NoErr(err)
+
+	rows, err := r.ReadAll()
+	is.NoErr(err)
+	is.Equal(len(rows), 2)
+	is.Equal(rows[0], []interface{}{int64(1), "Alice"})
+	is.Equal(rows[1], []interface{}{int64(2), "Bob"})
+}
+
+func TestResource_Cast(t *testing.T) {
+	is := is.New(t)
+	r, err := NewUncheckedResource(map[string]interface{}{
+		"name": "res",
+		"data": "id,name\n1,Alice\n2,Bob\n",
+		"schema": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "id", "type": "integer"},
+				map[string]interface{}{"name": "name", "type": "string"},
+			},
+		},
+	})
+	is.NoErr(err)
+
+	type person struct {
+		ID   int64  `tableschema:"id"`
+		Name string `tableschema:"name"`
+	}
+	var people []person
+	is.NoErr(r.Cast(&people))
+	is.Equal(people, []person{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}})
+}
+
+func TestResource_ReadAll_NoSchema(t *testing.T) {
+	is := is.New(t)
+	r, err := NewUncheckedResource(map[string]interface{}{"name": "res", "data": "a,b\n1,2\n"})
+	is.NoErr(err)
+	_, err = r.ReadAll()
+	is.True(err != nil)
+}
+
+func TestResource_ReadAll_Remote(t *testing.T) {
+	is := is.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("id,name\n1,Alice\n2,Bob\n"))
+	}))
+	defer srv.Close()
+
+	r, err := NewUncheckedResource(map[string]interface{}{
+		"name": "res",
+		"path": srv.URL + "/data.csv",
+		"schema": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "id", "type": "integer"},
+				map[string]interface{}{"name": "name", "type": "string"},
+			},
+		},
+	})
+	is.NoErr(err)
+
+	rows, err := r.ReadAll()
+	is.NoErr(err)
+	is.Equal(len(rows), 2)
+
+	_, err = r.ReadAll(WithIterMaxBytes(4))
+	is.True(err != nil)
+}
+
+func TestResource_ReadAll_Remote_ExactlyAtMaxBytes(t *testing.T) {
+	is := is.New(t)
+	body := "id\n1\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	r, err := NewUncheckedResource(map[string]interface{}{
+		"name": "res",
+		"path": srv.URL + "/data.csv",
+		"schema": map[string]interface{}{
+			"fields": []interface{}{map[string]interface{}{"name": "id", "type": "integer"}},
+		},
+	})
+	is.NoErr(err)
+
+	_, err = r.ReadAll(WithIterMaxBytes(int64(len(body))))
+	is.NoErr(err)
+
+	_, err = r.ReadAll(WithIterMaxBytes(int64(len(body)) - 1))
+	is.True(err != nil)
+}
+
+func TestResource_ReadAll_RejectsUnsafeLocalPath(t *testing.T) {
+	for _, path := range []string{"../secret.csv", "/etc/passwd", "a/../../secret.csv"} {
+		t.Run(path, func(t *testing.T) {
+			is := is.New(t)
+			r, err := NewUncheckedResource(map[string]interface{}{
+				"name": "res",
+				"path": path,
+				"schema": map[string]interface{}{
+					"fields": []interface{}{map[string]interface{}{"name": "id", "type": "integer"}},
+				},
+			})
+			is.NoErr(err)
+			_, err = r.ReadAll()
+			is.True(err != nil)
+		})
+	}
+}
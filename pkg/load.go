@@ -0,0 +1,213 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRedirects = 10
+	// defaultMaxBytes bounds a single fetched descriptor, guarding against
+	// descriptor bombs served by a malicious or misbehaving endpoint.
+	defaultMaxBytes = 50 << 20 // 50MiB
+)
+
+// LoadOption configures a call to Load.
+type LoadOption func(*loadConfig)
+
+type loadConfig struct {
+	client       *http.Client
+	timeout      time.Duration
+	maxRedirects int
+	maxBytes     int64
+}
+
+// WithHTTPClient overrides the http.Client used to fetch remote descriptors.
+// The client is copied before use, so its CheckRedirect is not mutated.
+func WithHTTPClient(c *http.Client) LoadOption {
+	return func(cfg *loadConfig) { cfg.client = c }
+}
+
+// WithTimeout bounds how long Load waits for any single descriptor fetch.
+func WithTimeout(d time.Duration) LoadOption {
+	return func(cfg *loadConfig) { cfg.timeout = d }
+}
+
+// WithMaxRedirects bounds how many redirects Load follows per fetch.
+func WithMaxRedirects(n int) LoadOption {
+	return func(cfg *loadConfig) { cfg.maxRedirects = n }
+}
+
+// WithMaxBytes bounds the size of any single fetched descriptor.
+func WithMaxBytes(n int64) LoadOption {
+	return func(cfg *loadConfig) { cfg.maxBytes = n }
+}
+
+// Load fetches the package descriptor at rawURL (an http(s):// or file://
+// URL), dereferencing resources whose value is a string pointing at another
+// JSON descriptor, and resolves every resource's "path" per the Frictionless
+// Data spec: an inline resource's against rawURL, a dereferenced resource's
+// against the URL it was fetched from. Dereferencing is one level deep:
+// string values inside a fetched resource descriptor (e.g. a "schema" URL)
+// are left as they are. The resulting descriptor is built into a Package via
+// factory.
+func Load(rawURL string, factory resourceFactory, opts ...LoadOption) (*Package, error) {
+	cfg := loadConfig{
+		client:       http.DefaultClient,
+		timeout:      defaultTimeout,
+		maxRedirects: defaultMaxRedirects,
+		maxBytes:     defaultMaxBytes,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package URL %q: %w", rawURL, err)
+	}
+
+	l := &loader{cfg: cfg}
+	d, err := l.fetchDescriptor(base)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.resolveResources(base, d); err != nil {
+		return nil, err
+	}
+	return fromDescriptor(d, factory)
+}
+
+// loader holds the configuration for a single Load call.
+type loader struct {
+	cfg loadConfig
+}
+
+// fetchDescriptor downloads and JSON-decodes the descriptor at u.
+func (l *loader) fetchDescriptor(u *url.URL) (map[string]interface{}, error) {
+	r, err := l.open(u)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	buf, err := io.ReadAll(io.LimitReader(r, l.cfg.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading descriptor at %s: %w", u, err)
+	}
+	if int64(len(buf)) > l.cfg.maxBytes {
+		return nil, fmt.Errorf("descriptor at %s exceeds maximum size of %d bytes", u, l.cfg.maxBytes)
+	}
+	var d map[string]interface{}
+	if err := json.Unmarshal(buf, &d); err != nil {
+		return nil, fmt.Errorf("failed decoding descriptor at %s: %w", u, err)
+	}
+	return d, nil
+}
+
+// open returns a reader over u's contents, supporting http(s):// and
+// file:// (or bare path) URLs.
+func (l *loader) open(u *url.URL) (io.ReadCloser, error) {
+	switch u.Scheme {
+	case "", "file":
+		f, err := os.Open(u.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening %s: %w", u, err)
+		}
+		return f, nil
+	case "http", "https":
+		client := *l.cfg.client
+		client.Timeout = l.cfg.timeout
+		redirects := 0
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			redirects++
+			if redirects > l.cfg.maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", l.cfg.maxRedirects)
+			}
+			return nil
+		}
+		resp, err := client.Get(u.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching %s: %w", u, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("fetching %s: unexpected status %s", u, resp.Status)
+		}
+		return resp.Body, nil
+	default:
+		return nil, fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+}
+
+// resolveResources dereferences string resource entries and rewrites every
+// resource's "path" in place: an inline resource's is resolved against base,
+// a dereferenced resource's against its own descriptor URL.
+func (l *loader) resolveResources(base *url.URL, d map[string]interface{}) error {
+	raw, ok := d["resources"]
+	if !ok {
+		return nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return fmt.Errorf(`"resources" property must be an array`)
+	}
+	for i, item := range list {
+		switch v := item.(type) {
+		case string:
+			ref, err := base.Parse(v)
+			if err != nil {
+				return fmt.Errorf("resolving resource reference %q: %w", v, err)
+			}
+			rd, err := l.fetchDescriptor(ref)
+			if err != nil {
+				return fmt.Errorf("dereferencing resource %q: %w", v, err)
+			}
+			if err := resolvePath(ref, rd); err != nil {
+				return err
+			}
+			list[i] = rd
+		case map[string]interface{}:
+			if err := resolvePath(base, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("resource at index %d is not a valid descriptor", i)
+		}
+	}
+	d["resources"] = list
+	return nil
+}
+
+// resolvePath rewrites rd's "path" property (a string or array of strings)
+// to be resolved against base, per the Frictionless Data rule that resource
+// paths are relative to the package's base URL.
+func resolvePath(base *url.URL, rd map[string]interface{}) error {
+	switch p := rd["path"].(type) {
+	case string:
+		resolved, err := base.Parse(p)
+		if err != nil {
+			return fmt.Errorf("resolving resource path %q: %w", p, err)
+		}
+		rd["path"] = resolved.String()
+	case []interface{}:
+		for i, pp := range p {
+			s, ok := pp.(string)
+			if !ok {
+				continue
+			}
+			resolved, err := base.Parse(s)
+			if err != nil {
+				return fmt.Errorf("resolving resource path %q: %w", s, err)
+			}
+			p[i] = resolved.String()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("HTTP", func(t *testing.T) {
+		is := is.New(t)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/datapackage.json":
+				w.Write([]byte(`{"resources":[{"name":"res","path":"data.csv"}]}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+
+		p, err := Load(srv.URL+"/datapackage.json", NewUncheckedResource)
+		is.NoErr(err)
+		is.Equal(p.GetResource("res").Name, "res")
+
+		d, err := p.Descriptor()
+		is.NoErr(err)
+		resources := d["resources"].([]interface{})
+		is.Equal(resources[0].(map[string]interface{})["path"], srv.URL+"/data.csv")
+	})
+
+	t.Run("DescriptorTooLarge", func(t *testing.T) {
+		is := is.New(t)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"resources":[{"name":"res","path":"data.csv"}]}`))
+		}))
+		defer srv.Close()
+
+		_, err := Load(srv.URL, NewUncheckedResource, WithMaxBytes(4))
+		is.True(err != nil)
+	})
+
+	t.Run("UnsupportedScheme", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Load("ftp://example.com/datapackage.json", NewUncheckedResource)
+		is.True(err != nil)
+	})
+
+	t.Run("DereferencesStringResource", func(t *testing.T) {
+		is := is.New(t)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/datapackage.json":
+				w.Write([]byte(`{"resources":["resources/res.json"]}`))
+			case "/resources/res.json":
+				w.Write([]byte(`{"name":"res","path":"data.csv"}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+
+		p, err := Load(srv.URL+"/datapackage.json", NewUncheckedResource)
+		is.NoErr(err)
+		is.Equal(p.GetResource("res").Name, "res")
+
+		d, err := p.Descriptor()
+		is.NoErr(err)
+		resources := d["resources"].([]interface{})
+		// The dereferenced resource's "path" resolves against its own URL
+		// (resources/res.json), not the package descriptor's.
+		is.Equal(resources[0].(map[string]interface{})["path"], srv.URL+"/resources/data.csv")
+	})
+
+	t.Run("FileURL", func(t *testing.T) {
+		is := is.New(t)
+		dir := t.TempDir()
+		path := filepath.Join(dir, "datapackage.json")
+		is.NoErr(os.WriteFile(path, []byte(`{"resources":[{"name":"res","path":"data.csv"}]}`), 0o644))
+
+		p, err := Load("file://"+path, NewUncheckedResource)
+		is.NoErr(err)
+		is.Equal(p.GetResource("res").Name, "res")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		is := is.New(t)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(`{"resources":[{"name":"res","path":"data.csv"}]}`))
+		}))
+		defer srv.Close()
+
+		_, err := Load(srv.URL, NewUncheckedResource, WithTimeout(time.Millisecond))
+		is.True(err != nil)
+	})
+
+	t.Run("MaxRedirects", func(t *testing.T) {
+		is := is.New(t)
+		var srv *httptest.Server
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, srv.URL+"/next", http.StatusFound)
+		}))
+		defer srv.Close()
+
+		_, err := Load(srv.URL, NewUncheckedResource, WithMaxRedirects(0))
+		is.True(err != nil)
+	})
+
+	t.Run("HTTPClient", func(t *testing.T) {
+		is := is.New(t)
+		var gotHeader string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotHeader = r.Header.Get("X-Test")
+			w.Write([]byte(`{"resources":[{"name":"res","path":"data.csv"}]}`))
+		}))
+		defer srv.Close()
+
+		client := &http.Client{Transport: headerRoundTripper{http.DefaultTransport}}
+		_, err := Load(srv.URL, NewUncheckedResource, WithHTTPClient(client))
+		is.NoErr(err)
+		is.Equal(gotHeader, "set")
+	})
+}
+
+// headerRoundTripper adds a fixed header to every request, so tests can
+// confirm a custom http.Client (via WithHTTPClient) is actually used.
+type headerRoundTripper struct {
+	base http.RoundTripper
+}
+
+func (rt headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Test", "set")
+	return rt.base.RoundTrip(req)
+}
+
+func TestResolvePath(t *testing.T) {
+	is := is.New(t)
+	base, err := url.Parse("https://example.com/data/datapackage.json")
+	is.NoErr(err)
+
+	rd := map[string]interface{}{"path": "resource.csv"}
+	is.NoErr(resolvePath(base, rd))
+	is.Equal(rd["path"], "https://example.com/data/resource.csv")
+
+	rd = map[string]interface{}{"path": []interface{}{"a.csv", "b.csv"}}
+	is.NoErr(resolvePath(base, rd))
+	paths := rd["path"].([]interface{})
+	is.Equal(paths[0], "https://example.com/data/a.csv")
+	is.Equal(paths[1], "https://example.com/data/b.csv")
+}
@@ -0,0 +1,177 @@
+// Package pkg implements the core Frictionless Data Package model: parsing,
+// validating and mutating package descriptors and the resources they
+// reference.
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Package represents a Frictionless Data Package descriptor together with
+// the Resources it describes.
+//
+// A Package is safe for concurrent use: mu guards every field below against
+// concurrent reads and writes, including those made by a Package opened via
+// OpenPackage while WatchAndReload is running in its own goroutine.
+type Package struct {
+	mu sync.RWMutex
+
+	descriptor map[string]interface{}
+	resources  []*Resource
+	resFactory resourceFactory
+
+	// store and version are set when the Package was opened via
+	// OpenPackage, so mutations are persisted with optimistic concurrency
+	// instead of only living in memory.
+	store   Store
+	version string
+}
+
+// New builds a Package from an already-decoded descriptor, building a
+// Resource for every entry of its "resources" property via factory. If opts
+// is non-empty, the descriptor is additionally validated against the
+// selected JSON schema profile (e.g. WithProfile(ProfileTabularDataPackage))
+// before resources are built.
+func New(d map[string]interface{}, factory resourceFactory, opts ...ValidateOption) (*Package, error) {
+	return fromDescriptor(d, factory, opts...)
+}
+
+// NewFromReader decodes a descriptor from r and builds a Package from it, as
+// New does.
+func NewFromReader(r io.Reader, factory resourceFactory, opts ...ValidateOption) (*Package, error) {
+	return fromReader(r, factory, opts...)
+}
+
+// fromDescriptor builds a Package from an already-decoded descriptor,
+// building a Resource for every entry of its "resources" property via
+// factory. If opts is non-empty, the descriptor is additionally validated
+// against the selected JSON schema profile before resources are built.
+func fromDescriptor(d map[string]interface{}, factory resourceFactory, opts ...ValidateOption) (*Package, error) {
+	if len(opts) > 0 {
+		if err := Validate(d, opts...); err != nil {
+			return nil, err
+		}
+	}
+	rawResources, ok := d["resources"]
+	if !ok {
+		return nil, fmt.Errorf(`descriptor must contain a non-empty "resources" property`)
+	}
+	resourceList, ok := rawResources.([]interface{})
+	if !ok || len(resourceList) == 0 {
+		return nil, fmt.Errorf(`descriptor must contain a non-empty "resources" property`)
+	}
+	resources := make([]*Resource, len(resourceList))
+	for i, raw := range resourceList {
+		rd, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("resource at index %d is not a valid descriptor", i)
+		}
+		r, err := factory(rd)
+		if err != nil {
+			return nil, fmt.Errorf("resource at index %d: %w", i, err)
+		}
+		resources[i] = r
+	}
+	return &Package{descriptor: d, resources: resources, resFactory: factory}, nil
+}
+
+// fromReader decodes a descriptor from r and builds a Package from it.
+func fromReader(r io.Reader, factory resourceFactory, opts ...ValidateOption) (*Package, error) {
+	var d map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("failed decoding descriptor: %w", err)
+	}
+	return fromDescriptor(d, factory, opts...)
+}
+
+// valid reports whether d can be turned into a Package via factory.
+func valid(d map[string]interface{}, factory resourceFactory) bool {
+	_, err := fromDescriptor(d, factory)
+	return err == nil
+}
+
+// GetResource returns the Resource named name, or nil if there is none.
+func (p *Package) GetResource(name string) *Resource {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, r := range p.resources {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// ResourceNames returns the names of every Resource in the Package, in
+// descriptor order.
+func (p *Package) ResourceNames() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, len(p.resources))
+	for i, r := range p.resources {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// AddResource builds a Resource from d via the Package's resFactory and
+// appends it, updating the underlying descriptor in place. It is
+// equivalent to AddResourceContext(context.Background(), d).
+func (p *Package) AddResource(d map[string]interface{}) error {
+	return p.AddResourceContext(context.Background(), d)
+}
+
+// RemoveResource removes the Resource named name, if present, along with its
+// entry in the underlying descriptor. It is a no-op if name is not found,
+// and equivalent to RemoveResourceContext(context.Background(), name),
+// ignoring any persistence error from a Package opened via OpenPackage.
+func (p *Package) RemoveResource(name string) {
+	_ = p.RemoveResourceContext(context.Background(), name)
+}
+
+// Descriptor returns a deep copy of the Package's underlying descriptor.
+func (p *Package) Descriptor() (map[string]interface{}, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return deepCopyDescriptor(p.descriptor)
+}
+
+// Update replaces the Package's descriptor wholesale, rebuilding every
+// Resource via the Package's resFactory. The Package is left untouched if
+// newDescriptor fails to build. It is equivalent to
+// UpdateContext(context.Background(), newDescriptor).
+func (p *Package) Update(newDescriptor map[string]interface{}) error {
+	return p.UpdateContext(context.Background(), newDescriptor)
+}
+
+// UnmarshalJSON decodes a descriptor from data, structurally validating it
+// with NewUncheckedResource. It does not populate resFactory or resources;
+// callers that need those should go through fromReader/fromDescriptor.
+func (p *Package) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	d, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("descriptor must be a JSON object")
+	}
+	if !valid(d, NewUncheckedResource) {
+		return fmt.Errorf("invalid descriptor")
+	}
+	p.mu.Lock()
+	p.descriptor = d
+	p.mu.Unlock()
+	return nil
+}
+
+// MarshalJSON encodes the Package's underlying descriptor.
+func (p *Package) MarshalJSON() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return json.Marshal(p.descriptor)
+}
@@ -216,6 +216,33 @@ func TestFromReader(t *testing.T) {
 	})
 }
 
+func TestNew(t *testing.T) {
+	t.Run("DefaultsToNoValidation", func(t *testing.T) {
+		is := is.New(t)
+		_, err := New(map[string]interface{}{
+			"resources": []interface{}{map[string]interface{}{"name": "res", "path": "data.csv"}},
+		}, NewUncheckedResource)
+		is.NoErr(err)
+	})
+	t.Run("SelectedProfileRejectsInvalidDescriptor", func(t *testing.T) {
+		is := is.New(t)
+		_, err := New(map[string]interface{}{
+			"resources": []interface{}{map[string]interface{}{"name": "res", "path": "data.csv"}},
+		}, NewUncheckedResource, WithProfile(ProfileTabularDataPackage))
+		is.True(err != nil)
+	})
+}
+
+func TestNewFromReader(t *testing.T) {
+	is := is.New(t)
+	_, err := NewFromReader(
+		strings.NewReader(`{"resources":[{"name":"res","path":"data.csv"}]}`),
+		NewUncheckedResource,
+		WithProfile(ProfileTabularDataPackage),
+	)
+	is.True(err != nil)
+}
+
 func TestValid(t *testing.T) {
 	is := is.New(t)
 	is.True(valid(map[string]interface{}{"resources": []interface{}{map[string]interface{}{"name": "res"}}}, NewUncheckedResource))
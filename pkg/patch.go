@@ -0,0 +1,230 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// PatchOp is a single RFC 6902 JSON Patch operation.
+type PatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch applies ops to the Package's descriptor as an RFC 6902 JSON Patch,
+// re-materializing every Resource via the Package's resFactory. The
+// Package is left untouched if any operation, or the resulting descriptor,
+// fails to apply. It is equivalent to PatchContext(context.Background(), ops).
+func (p *Package) Patch(ops []PatchOp) error {
+	return p.PatchContext(context.Background(), ops)
+}
+
+// PatchContext is the context-aware, store-persisting counterpart to Patch.
+func (p *Package) PatchContext(ctx context.Context, ops []PatchOp) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	doc, err := deepCopyDescriptor(p.descriptor)
+	if err != nil {
+		return err
+	}
+	var tree interface{} = doc
+	for i, op := range ops {
+		if err := applyPatchOp(&tree, op); err != nil {
+			return fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	newDescriptor, ok := tree.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("patch must leave the descriptor a JSON object, got %T", tree)
+	}
+
+	np, err := fromDescriptor(newDescriptor, p.resFactory)
+	if err != nil {
+		return fmt.Errorf("patched descriptor is invalid: %w", err)
+	}
+	if err := p.persist(ctx, np.descriptor); err != nil {
+		return err
+	}
+	p.descriptor = np.descriptor
+	p.resources = np.resources
+	return nil
+}
+
+// Get resolves pointer (an RFC 6901 JSON Pointer) against the Package's
+// descriptor and returns a deep copy of the value found there.
+func (p *Package) Get(pointer string) (interface{}, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	v, err := getPointer(interface{}(p.descriptor), pointer)
+	if err != nil {
+		return nil, err
+	}
+	return deepCopyValue(v), nil
+}
+
+// Set replaces (or creates) the value at pointer (an RFC 6901 JSON Pointer)
+// with value, re-materializing every Resource via the Package's resFactory.
+// The Package is left untouched if the resulting descriptor fails to build.
+// It is equivalent to SetContext(context.Background(), pointer, value).
+func (p *Package) Set(pointer string, value interface{}) error {
+	return p.SetContext(context.Background(), pointer, value)
+}
+
+// SetContext is the context-aware, store-persisting counterpart to Set.
+func (p *Package) SetContext(ctx context.Context, pointer string, value interface{}) error {
+	p.mu.RLock()
+	_, err := getPointer(interface{}(p.descriptor), pointer)
+	p.mu.RUnlock()
+	op := "add"
+	if err == nil {
+		op = "replace"
+	}
+	return p.PatchContext(ctx, []PatchOp{{Op: op, Path: pointer, Value: value}})
+}
+
+func applyPatchOp(tree *interface{}, op PatchOp) error {
+	switch op.Op {
+	case "add":
+		return patchAdd(tree, op.Path, op.Value)
+	case "remove":
+		return patchRemove(tree, op.Path)
+	case "replace":
+		return patchReplace(tree, op.Path, op.Value)
+	case "move":
+		v, err := getPointer(*tree, op.From)
+		if err != nil {
+			return err
+		}
+		if err := patchRemove(tree, op.From); err != nil {
+			return err
+		}
+		return patchAdd(tree, op.Path, v)
+	case "copy":
+		v, err := getPointer(*tree, op.From)
+		if err != nil {
+			return err
+		}
+		return patchAdd(tree, op.Path, deepCopyValue(v))
+	case "test":
+		v, err := getPointer(*tree, op.Path)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(v, op.Value) {
+			return fmt.Errorf("test failed: value at %q does not match", op.Path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+func patchAdd(tree *interface{}, pointer string, value interface{}) error {
+	if pointer == "" {
+		*tree = deepCopyValue(value)
+		return nil
+	}
+	toks, err := tokens(pointer)
+	if err != nil {
+		return err
+	}
+	newTree, err := applyAt(*tree, toks, func(parent interface{}, key string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			v[key] = deepCopyValue(value)
+			return v, nil
+		case []interface{}:
+			idx, err := arrayIndexForInsert(key, len(v))
+			if err != nil {
+				return nil, err
+			}
+			out := make([]interface{}, 0, len(v)+1)
+			out = append(out, v[:idx]...)
+			out = append(out, deepCopyValue(value))
+			out = append(out, v[idx:]...)
+			return out, nil
+		default:
+			return nil, fmt.Errorf("cannot add into %T", parent)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("pointer %q: %w", pointer, err)
+	}
+	*tree = newTree
+	return nil
+}
+
+func patchRemove(tree *interface{}, pointer string) error {
+	if pointer == "" {
+		return fmt.Errorf("cannot remove the document root")
+	}
+	toks, err := tokens(pointer)
+	if err != nil {
+		return err
+	}
+	newTree, err := applyAt(*tree, toks, func(parent interface{}, key string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[key]; !ok {
+				return nil, fmt.Errorf("no member %q", key)
+			}
+			delete(v, key)
+			return v, nil
+		case []interface{}:
+			idx, err := arrayIndexForRead(key, len(v))
+			if err != nil {
+				return nil, err
+			}
+			out := make([]interface{}, 0, len(v)-1)
+			out = append(out, v[:idx]...)
+			out = append(out, v[idx+1:]...)
+			return out, nil
+		default:
+			return nil, fmt.Errorf("cannot remove from %T", parent)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("pointer %q: %w", pointer, err)
+	}
+	*tree = newTree
+	return nil
+}
+
+func patchReplace(tree *interface{}, pointer string, value interface{}) error {
+	if pointer == "" {
+		*tree = deepCopyValue(value)
+		return nil
+	}
+	toks, err := tokens(pointer)
+	if err != nil {
+		return err
+	}
+	newTree, err := applyAt(*tree, toks, func(parent interface{}, key string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[key]; !ok {
+				return nil, fmt.Errorf("no member %q", key)
+			}
+			v[key] = deepCopyValue(value)
+			return v, nil
+		case []interface{}:
+			idx, err := arrayIndexForRead(key, len(v))
+			if err != nil {
+				return nil, err
+			}
+			v[idx] = deepCopyValue(value)
+			return v, nil
+		default:
+			return nil, fmt.Errorf("cannot replace within %T", parent)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("pointer %q: %w", pointer, err)
+	}
+	*tree = newTree
+	return nil
+}
@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func newTestPackage(t *testing.T) *Package {
+	t.Helper()
+	p, err := fromDescriptor(map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{"name": "res1"},
+			map[string]interface{}{"name": "res2"},
+		},
+	}, NewUncheckedResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPackage_GetSet(t *testing.T) {
+	is := is.New(t)
+	p := newTestPackage(t)
+
+	v, err := p.Get("/resources/0/name")
+	is.NoErr(err)
+	is.Equal(v, "res1")
+
+	is.NoErr(p.Set("/resources/0/title", "Resource One"))
+	v, err = p.Get("/resources/0/title")
+	is.NoErr(err)
+	is.Equal(v, "Resource One")
+}
+
+func TestPackage_Patch(t *testing.T) {
+	t.Run("Add", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.NoErr(p.Patch([]PatchOp{
+			{Op: "add", Path: "/resources/-", Value: map[string]interface{}{"name": "res3"}},
+		}))
+		is.Equal(p.ResourceNames(), []string{"res1", "res2", "res3"})
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.NoErr(p.Patch([]PatchOp{{Op: "remove", Path: "/resources/0"}}))
+		is.Equal(p.ResourceNames(), []string{"res2"})
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.NoErr(p.Patch([]PatchOp{{Op: "replace", Path: "/resources/0/name", Value: "renamed"}}))
+		is.Equal(p.ResourceNames(), []string{"renamed", "res2"})
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.NoErr(p.Patch([]PatchOp{{Op: "move", From: "/resources/0", Path: "/resources/1"}}))
+		is.Equal(p.ResourceNames(), []string{"res2", "res1"})
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.NoErr(p.Patch([]PatchOp{{Op: "copy", From: "/resources/0", Path: "/resources/-"}}))
+		is.Equal(p.ResourceNames(), []string{"res1", "res2", "res1"})
+	})
+
+	t.Run("Test", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.NoErr(p.Patch([]PatchOp{{Op: "test", Path: "/resources/0/name", Value: "res1"}}))
+
+		err := p.Patch([]PatchOp{{Op: "test", Path: "/resources/0/name", Value: "nope"}})
+		is.True(err != nil)
+	})
+
+	t.Run("RollsBackOnInvalidResult", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		before := p.ResourceNames()
+
+		err := p.Patch([]PatchOp{{Op: "remove", Path: "/resources/0"}, {Op: "remove", Path: "/resources/0"}})
+		is.True(err != nil) // would empty the required "resources" array
+		is.Equal(p.ResourceNames(), before)
+	})
+
+	t.Run("UnknownOp", func(t *testing.T) {
+		is := is.New(t)
+		p := newTestPackage(t)
+		is.True(p.Patch([]PatchOp{{Op: "bogus", Path: "/resources/0"}}) != nil)
+	})
+}
+
+func TestPackage_Patch_PersistsToStore(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	store := NewMemoryStore(map[string]interface{}{
+		"resources": []interface{}{map[string]interface{}{"name": "res1"}},
+	})
+	p, err := OpenPackage(ctx, store, NewUncheckedResource)
+	is.NoErr(err)
+	versionBefore := p.version
+
+	is.NoErr(p.Patch([]PatchOp{
+		{Op: "add", Path: "/resources/-", Value: map[string]interface{}{"name": "res2"}},
+	}))
+	is.True(p.version != versionBefore)
+
+	d, _, err := store.Load(ctx)
+	is.NoErr(err)
+	is.Equal(len(d["resources"].([]interface{})), 2)
+
+	is.NoErr(p.Set("/resources/0/title", "Resource One"))
+	d, _, err = store.Load(ctx)
+	is.NoErr(err)
+	is.Equal(d["resources"].([]interface{})[0].(map[string]interface{})["title"], "Resource One")
+}
@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokens splits an RFC 6901 JSON Pointer into its unescaped reference
+// tokens. The root pointer ("") yields no tokens.
+func tokens(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q: must start with \"/\"", pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i, p := range parts {
+		p = strings.ReplaceAll(p, "~1", "/")
+		p = strings.ReplaceAll(p, "~0", "~")
+		parts[i] = p
+	}
+	return parts, nil
+}
+
+// arrayIndexForRead parses tok as an index into an existing array of the
+// given length, rejecting "-" (which only makes sense as an insert
+// position) and out-of-range indices.
+func arrayIndexForRead(tok string, length int) (int, error) {
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || idx >= length {
+		return 0, fmt.Errorf("invalid array index %q for length %d", tok, length)
+	}
+	return idx, nil
+}
+
+// arrayIndexForInsert parses tok as a position to insert into an array of
+// the given length, treating "-" as "after the last element".
+func arrayIndexForInsert(tok string, length int) (int, error) {
+	if tok == "-" {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || idx > length {
+		return 0, fmt.Errorf("invalid array index %q for length %d", tok, length)
+	}
+	return idx, nil
+}
+
+// getPointer resolves pointer against doc, per RFC 6901.
+func getPointer(doc interface{}, pointer string) (interface{}, error) {
+	toks, err := tokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	cur := doc
+	for _, tok := range toks {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[tok]
+			if !ok {
+				return nil, fmt.Errorf("pointer %q: no member %q", pointer, tok)
+			}
+			cur = next
+		case []interface{}:
+			idx, err := arrayIndexForRead(tok, len(v))
+			if err != nil {
+				return nil, fmt.Errorf("pointer %q: %w", pointer, err)
+			}
+			cur = v[idx]
+		default:
+			return nil, fmt.Errorf("pointer %q: cannot descend into %T", pointer, cur)
+		}
+	}
+	return cur, nil
+}
+
+// applyAt walks toks into container and calls mutate on the effective
+// parent/key, rewriting every container along the path to the mutate
+// callback's returned value. It returns the (possibly new, for arrays)
+// value of container itself.
+func applyAt(container interface{}, toks []string, mutate func(parent interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("must reference a non-root location")
+	}
+	if len(toks) == 1 {
+		return mutate(container, toks[0])
+	}
+	tok := toks[0]
+	switch v := container.(type) {
+	case map[string]interface{}:
+		child, ok := v[tok]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", tok)
+		}
+		newChild, err := applyAt(child, toks[1:], mutate)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = newChild
+		return v, nil
+	case []interface{}:
+		idx, err := arrayIndexForRead(tok, len(v))
+		if err != nil {
+			return nil, err
+		}
+		newChild, err := applyAt(v[idx], toks[1:], mutate)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = newChild
+		return v, nil
+	default:
+		return nil, fmt.Errorf("cannot descend into %T", container)
+	}
+}
+
+// deepCopyValue returns a deep copy of an arbitrary decoded-JSON value by
+// round-tripping it through JSON, falling back to v itself if that fails.
+func deepCopyValue(v interface{}) interface{} {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var cp interface{}
+	if err := json.Unmarshal(buf, &cp); err != nil {
+		return v
+	}
+	return cp
+}
@@ -0,0 +1,29 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGetPointer(t *testing.T) {
+	is := is.New(t)
+	doc := map[string]interface{}{
+		"a/b":  map[string]interface{}{"c~d": "value"},
+		"list": []interface{}{"x", "y"},
+	}
+
+	v, err := getPointer(doc, "/a~1b/c~0d")
+	is.NoErr(err)
+	is.Equal(v, "value")
+
+	v, err = getPointer(doc, "/list/1")
+	is.NoErr(err)
+	is.Equal(v, "y")
+
+	_, err = getPointer(doc, "/list/5")
+	is.True(err != nil)
+
+	_, err = getPointer(doc, "nope")
+	is.True(err != nil)
+}
@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// resourceFactory builds a Resource from its raw descriptor, optionally
+// validating it along the way. It is the extension point Package uses to
+// turn descriptor maps into Resources, so callers can trade off strictness
+// (NewResource) against leniency (NewUncheckedResource).
+type resourceFactory func(map[string]interface{}) (*Resource, error)
+
+// Resource represents a single entry of a data package's "resources" array.
+type Resource struct {
+	Name string
+
+	descriptor map[string]interface{}
+	// fsys, when set, resolves the resource's "path" against an archive
+	// (e.g. a zip-packaged data package) instead of the local filesystem.
+	fsys fs.FS
+}
+
+// NewUncheckedResource creates a Resource from the given descriptor without
+// validating it against the Data Resource profile.
+func NewUncheckedResource(d map[string]interface{}) (*Resource, error) {
+	name, _ := d["name"].(string)
+	return &Resource{Name: name, descriptor: d}, nil
+}
+
+// NewResource creates a Resource from the given descriptor, validating it
+// against the Data Resource JSON schema before construction. It is the
+// strict counterpart to NewUncheckedResource, meant to be used as a
+// Package's resFactory so every AddResource/Update call is validated.
+func NewResource(d map[string]interface{}) (*Resource, error) {
+	if err := Validate(d, WithProfile(ProfileDataResource)); err != nil {
+		return nil, fmt.Errorf("invalid resource descriptor: %w", err)
+	}
+	return NewUncheckedResource(d)
+}
+
+// Descriptor returns a deep copy of the resource's underlying descriptor.
+func (r *Resource) Descriptor() (map[string]interface{}, error) {
+	return deepCopyDescriptor(r.descriptor)
+}
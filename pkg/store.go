@@ -0,0 +1,476 @@
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventType enumerates the kinds of change a Store.Watch can report.
+type EventType int
+
+// EventUpdated indicates the stored descriptor changed.
+const EventUpdated EventType = iota
+
+// Event is emitted by Store.Watch whenever the stored descriptor changes
+// out from under the current process.
+type Event struct {
+	Type EventType
+}
+
+// ErrVersionConflict is returned by Store.Save when the descriptor changed
+// since it was last Loaded, so callers know to reload and retry instead of
+// silently clobbering someone else's write.
+var ErrVersionConflict = errors.New("pkg: descriptor version conflict")
+
+// Store abstracts where a Package's descriptor lives, so AddResource/
+// RemoveResource/Update can persist every mutation instead of only holding
+// it in memory.
+type Store interface {
+	// Load reads the current descriptor and an opaque version token to be
+	// passed to the next Save.
+	Load(ctx context.Context) (desc map[string]interface{}, version string, err error)
+	// Save persists desc, failing with ErrVersionConflict if version no
+	// longer matches the store's current version. It returns the new
+	// version on success.
+	Save(ctx context.Context, desc map[string]interface{}, version string) (newVersion string, err error)
+	// Watch reports descriptor changes made by other processes. The
+	// returned channel is closed once ctx is done.
+	Watch(ctx context.Context) (<-chan Event, error)
+}
+
+// OpenPackage loads a descriptor from store and builds a Package backed by
+// it, so subsequent AddResource/RemoveResource/Update calls persist through
+// store with optimistic concurrency.
+func OpenPackage(ctx context.Context, store Store, factory resourceFactory) (*Package, error) {
+	d, version, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading descriptor from store: %w", err)
+	}
+	p, err := fromDescriptor(d, factory)
+	if err != nil {
+		return nil, err
+	}
+	p.store = store
+	p.version = version
+	return p, nil
+}
+
+// WatchAndReload watches the Package's store for external changes,
+// reloading the descriptor and rebuilding Resources whenever one is
+// observed. It blocks until ctx is done or the store's Watch channel
+// closes, so callers typically run it in its own goroutine.
+func (p *Package) WatchAndReload(ctx context.Context) error {
+	p.mu.RLock()
+	store := p.store
+	p.mu.RUnlock()
+	if store == nil {
+		return fmt.Errorf("package has no store configured")
+	}
+	events, err := store.Watch(ctx)
+	if err != nil {
+		return err
+	}
+	for range events {
+		d, version, err := store.Load(ctx)
+		if err != nil {
+			continue
+		}
+		p.mu.Lock()
+		np, err := fromDescriptor(d, p.resFactory)
+		if err != nil {
+			p.mu.Unlock()
+			continue
+		}
+		p.descriptor = np.descriptor
+		p.resources = np.resources
+		p.version = version
+		p.mu.Unlock()
+	}
+	return nil
+}
+
+// persist saves newDescriptor through the Package's store, if any, and
+// advances p.version on success. It is a no-op for Packages without a
+// store. Callers must hold p.mu for writing.
+func (p *Package) persist(ctx context.Context, newDescriptor map[string]interface{}) error {
+	if p.store == nil {
+		return nil
+	}
+	newVersion, err := p.store.Save(ctx, newDescriptor, p.version)
+	if err != nil {
+		return err
+	}
+	p.version = newVersion
+	return nil
+}
+
+// AddResourceContext is the context-aware, store-persisting counterpart to
+// AddResource.
+func (p *Package) AddResourceContext(ctx context.Context, d map[string]interface{}) error {
+	if p.resFactory == nil {
+		return fmt.Errorf("package has no resource factory set")
+	}
+	r, err := p.resFactory(d)
+	if err != nil {
+		return fmt.Errorf("invalid resource: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	newDescriptor, err := deepCopyDescriptor(p.descriptor)
+	if err != nil {
+		return err
+	}
+	if newDescriptor == nil {
+		newDescriptor = map[string]interface{}{}
+	}
+	resources, _ := newDescriptor["resources"].([]interface{})
+	newDescriptor["resources"] = append(resources, d)
+
+	if err := p.persist(ctx, newDescriptor); err != nil {
+		return err
+	}
+	p.descriptor = newDescriptor
+	p.resources = append(p.resources, r)
+	return nil
+}
+
+// RemoveResourceContext is the context-aware, store-persisting counterpart
+// to RemoveResource. Unlike RemoveResource, it reports a persistence
+// failure (e.g. ErrVersionConflict) instead of swallowing it.
+func (p *Package) RemoveResourceContext(ctx context.Context, name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idx := -1
+	for i, r := range p.resources {
+		if r.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+
+	newDescriptor, err := deepCopyDescriptor(p.descriptor)
+	if err != nil {
+		return err
+	}
+	if resources, ok := newDescriptor["resources"].([]interface{}); ok {
+		newDescriptor["resources"] = append(resources[:idx], resources[idx+1:]...)
+	}
+
+	if err := p.persist(ctx, newDescriptor); err != nil {
+		return err
+	}
+	p.descriptor = newDescriptor
+	p.resources = append(p.resources[:idx], p.resources[idx+1:]...)
+	return nil
+}
+
+// UpdateContext is the context-aware, store-persisting counterpart to
+// Update.
+func (p *Package) UpdateContext(ctx context.Context, newDescriptor map[string]interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	np, err := fromDescriptor(newDescriptor, p.resFactory)
+	if err != nil {
+		return err
+	}
+	if err := p.persist(ctx, np.descriptor); err != nil {
+		return err
+	}
+	p.descriptor = np.descriptor
+	p.resources = np.resources
+	return nil
+}
+
+// MemoryStore is an in-memory Store, primarily useful in tests.
+type MemoryStore struct {
+	mu       sync.Mutex
+	desc     map[string]interface{}
+	version  int
+	watchers []chan Event
+}
+
+// NewMemoryStore returns a MemoryStore seeded with desc.
+func NewMemoryStore(desc map[string]interface{}) *MemoryStore {
+	return &MemoryStore{desc: desc}
+}
+
+// Load implements Store.
+func (s *MemoryStore) Load(_ context.Context) (map[string]interface{}, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, err := deepCopyDescriptor(s.desc)
+	if err != nil {
+		return nil, "", err
+	}
+	return d, s.versionToken(), nil
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(_ context.Context, desc map[string]interface{}, version string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version != s.versionToken() {
+		return "", ErrVersionConflict
+	}
+	cp, err := deepCopyDescriptor(desc)
+	if err != nil {
+		return "", err
+	}
+	s.desc = cp
+	s.version++
+	for _, ch := range s.watchers {
+		select {
+		case ch <- Event{Type: EventUpdated}:
+		default:
+		}
+	}
+	return s.versionToken(), nil
+}
+
+// Watch implements Store.
+func (s *MemoryStore) Watch(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, 1)
+	s.mu.Lock()
+	s.watchers = append(s.watchers, ch)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, w := range s.watchers {
+			if w == ch {
+				s.watchers = append(s.watchers[:i], s.watchers[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
+
+func (s *MemoryStore) versionToken() string {
+	return fmt.Sprintf("%d", s.version)
+}
+
+// FileStore persists a descriptor to a local JSON file, using a content
+// hash as its optimistic-concurrency version token.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a FileStore backed by the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load implements Store.
+func (s *FileStore) Load(_ context.Context) (map[string]interface{}, string, error) {
+	buf, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed reading %s: %w", s.path, err)
+	}
+	var d map[string]interface{}
+	if err := json.Unmarshal(buf, &d); err != nil {
+		return nil, "", fmt.Errorf("failed decoding %s: %w", s.path, err)
+	}
+	return d, contentVersion(buf), nil
+}
+
+// Save implements Store.
+func (s *FileStore) Save(_ context.Context, desc map[string]interface{}, version string) (string, error) {
+	cur, err := os.ReadFile(s.path)
+	switch {
+	case err == nil:
+		if contentVersion(cur) != version {
+			return "", ErrVersionConflict
+		}
+	case os.IsNotExist(err):
+		if version != "" {
+			return "", ErrVersionConflict
+		}
+	default:
+		return "", fmt.Errorf("failed reading %s: %w", s.path, err)
+	}
+
+	buf, err := json.MarshalIndent(desc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed encoding descriptor: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o644); err != nil {
+		return "", fmt.Errorf("failed writing %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return "", fmt.Errorf("failed renaming %s to %s: %w", tmp, s.path, err)
+	}
+	return contentVersion(buf), nil
+}
+
+// Watch implements Store by polling the file for content changes once per
+// second.
+func (s *FileStore) Watch(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		last := ""
+		if buf, err := os.ReadFile(s.path); err == nil {
+			last = contentVersion(buf)
+		}
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				buf, err := os.ReadFile(s.path)
+				if err != nil {
+					continue
+				}
+				if v := contentVersion(buf); v != last {
+					last = v
+					select {
+					case ch <- Event{Type: EventUpdated}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func contentVersion(buf []byte) string {
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrObjectNotExist is returned by an S3API implementation's GetObject when
+// the object does not exist yet, letting S3Store distinguish "no current
+// version" from a transport error.
+var ErrObjectNotExist = errors.New("pkg: object does not exist")
+
+// S3API is the subset of an S3-compatible client's operations S3Store
+// needs, kept minimal so callers can adapt any SDK (or a fake, in tests)
+// without pulling a specific one into this package.
+type S3API interface {
+	// GetObject returns the object's body and ETag, or a wrapped
+	// ErrObjectNotExist if it doesn't exist.
+	GetObject(ctx context.Context, bucket, key string) (body io.ReadCloser, etag string, err error)
+	// PutObject writes body and returns the resulting ETag.
+	PutObject(ctx context.Context, bucket, key string, body []byte) (etag string, err error)
+}
+
+// S3Store persists a descriptor as a single object in an S3-compatible
+// object store, using the object's ETag as its optimistic-concurrency
+// version token.
+type S3Store struct {
+	client     S3API
+	bucket     string
+	key        string
+	pollPeriod time.Duration
+}
+
+// NewS3Store returns an S3Store for the object at bucket/key, fetched and
+// written through client.
+func NewS3Store(client S3API, bucket, key string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, key: key, pollPeriod: 5 * time.Second}
+}
+
+// Load implements Store.
+func (s *S3Store) Load(ctx context.Context) (map[string]interface{}, string, error) {
+	body, etag, err := s.client.GetObject(ctx, s.bucket, s.key)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed fetching s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+	defer body.Close()
+
+	buf, err := io.ReadAll(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed reading s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+	var d map[string]interface{}
+	if err := json.Unmarshal(buf, &d); err != nil {
+		return nil, "", fmt.Errorf("failed decoding s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+	return d, etag, nil
+}
+
+// Save implements Store. Concurrency is optimistic only: a GetObject
+// confirming the current ETag races with a concurrent writer's PutObject,
+// so S3-compatible stores with true conditional writes (e.g. "If-Match")
+// should prefer wiring that through a dedicated S3API implementation.
+func (s *S3Store) Save(ctx context.Context, desc map[string]interface{}, version string) (string, error) {
+	_, curEtag, err := s.client.GetObject(ctx, s.bucket, s.key)
+	switch {
+	case err == nil:
+		if curEtag != version {
+			return "", ErrVersionConflict
+		}
+	case errors.Is(err, ErrObjectNotExist):
+		if version != "" {
+			return "", ErrVersionConflict
+		}
+	default:
+		return "", fmt.Errorf("failed checking s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+
+	buf, err := json.Marshal(desc)
+	if err != nil {
+		return "", fmt.Errorf("failed encoding descriptor: %w", err)
+	}
+	etag, err := s.client.PutObject(ctx, s.bucket, s.key, buf)
+	if err != nil {
+		return "", fmt.Errorf("failed writing s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+	return etag, nil
+}
+
+// Watch implements Store by polling the object's ETag once per
+// s.pollPeriod.
+func (s *S3Store) Watch(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		_, last, _ := s.client.GetObject(ctx, s.bucket, s.key)
+		ticker := time.NewTicker(s.pollPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, etag, err := s.client.GetObject(ctx, s.bucket, s.key)
+				if err != nil {
+					continue
+				}
+				if etag != last {
+					last = etag
+					select {
+					case ch <- Event{Type: EventUpdated}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestOpenPackage_MemoryStore(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	store := NewMemoryStore(map[string]interface{}{
+		"resources": []interface{}{map[string]interface{}{"name": "res1"}},
+	})
+
+	p, err := OpenPackage(ctx, store, NewUncheckedResource)
+	is.NoErr(err)
+	is.Equal(p.ResourceNames(), []string{"res1"})
+
+	is.NoErr(p.AddResourceContext(ctx, map[string]interface{}{"name": "res2"}))
+	is.Equal(p.ResourceNames(), []string{"res1", "res2"})
+
+	// A second Package opened against the same store sees the persisted
+	// change and then races with p, producing a version conflict.
+	other, err := OpenPackage(ctx, store, NewUncheckedResource)
+	is.NoErr(err)
+	is.Equal(other.ResourceNames(), []string{"res1", "res2"})
+
+	is.NoErr(p.RemoveResourceContext(ctx, "res2"))
+	err = other.AddResourceContext(ctx, map[string]interface{}{"name": "res3"})
+	is.True(err == ErrVersionConflict)
+}
+
+func TestFileStore(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "datapackage.json")
+	store := NewFileStore(path)
+
+	version, err := store.Save(ctx, map[string]interface{}{
+		"resources": []interface{}{map[string]interface{}{"name": "res1"}},
+	}, "")
+	is.NoErr(err)
+
+	d, loadedVersion, err := store.Load(ctx)
+	is.NoErr(err)
+	is.Equal(loadedVersion, version)
+	is.Equal(d["resources"].([]interface{})[0].(map[string]interface{})["name"], "res1")
+
+	_, err = store.Save(ctx, map[string]interface{}{"resources": []interface{}{}}, "stale-version")
+	is.True(err == ErrVersionConflict)
+}
+
+// TestPackage_ConcurrentAccess exercises WatchAndReload running alongside
+// concurrent readers of the same Package, so `go test -race` catches any
+// field read outside p.mu.
+func TestPackage_ConcurrentAccess(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := NewMemoryStore(map[string]interface{}{
+		"resources": []interface{}{map[string]interface{}{"name": "res1"}},
+	})
+	p, err := OpenPackage(ctx, store, NewUncheckedResource)
+	is.NoErr(err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = p.WatchAndReload(ctx)
+	}()
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = p.Descriptor()
+			_ = p.ResourceNames()
+			_ = p.GetResource("res1")
+			_, _ = p.MarshalJSON()
+		}(i)
+	}
+
+	cancel()
+	wg.Wait()
+}
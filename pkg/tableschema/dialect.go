@@ -0,0 +1,32 @@
+package tableschema
+
+// Dialect describes the CSV dialect of a tabular resource, per the CSV
+// Dialect Description Format specification.
+type Dialect struct {
+	Delimiter        rune
+	QuoteChar        rune
+	Header           bool
+	SkipInitialSpace bool
+}
+
+// DefaultDialect is the dialect assumed when a resource declares none.
+var DefaultDialect = Dialect{Delimiter: ',', QuoteChar: '"', Header: true}
+
+// DialectFromDescriptor builds a Dialect from its JSON descriptor, defaulting
+// any property it doesn't set to DefaultDialect.
+func DialectFromDescriptor(d map[string]interface{}) Dialect {
+	dialect := DefaultDialect
+	if v, ok := d["delimiter"].(string); ok && len(v) > 0 {
+		dialect.Delimiter = rune(v[0])
+	}
+	if v, ok := d["quoteChar"].(string); ok && len(v) > 0 {
+		dialect.QuoteChar = rune(v[0])
+	}
+	if v, ok := d["header"].(bool); ok {
+		dialect.Header = v
+	}
+	if v, ok := d["skipInitialSpace"].(bool); ok {
+		dialect.SkipInitialSpace = v
+	}
+	return dialect
+}
@@ -0,0 +1,149 @@
+// Package tableschema implements the Table Schema specification: parsing
+// field and dialect descriptors and casting CSV cell values into Go types.
+package tableschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType enumerates the Table Schema field types this package can cast.
+type FieldType string
+
+// Field types supported by Field.Cast.
+const (
+	TypeString   FieldType = "string"
+	TypeNumber   FieldType = "number"
+	TypeInteger  FieldType = "integer"
+	TypeBoolean  FieldType = "boolean"
+	TypeDate     FieldType = "date"
+	TypeDateTime FieldType = "datetime"
+	TypeArray    FieldType = "array"
+	TypeObject   FieldType = "object"
+)
+
+const (
+	defaultDateLayout     = "2006-01-02"
+	defaultDateTimeLayout = time.RFC3339
+)
+
+// Field describes a single Table Schema column.
+type Field struct {
+	Name   string
+	Type   FieldType
+	Format string
+}
+
+// FieldFromDescriptor builds a Field from its JSON descriptor, defaulting
+// an unset or empty "type" to TypeString as the Table Schema spec requires.
+func FieldFromDescriptor(d map[string]interface{}) Field {
+	f := Field{Type: TypeString}
+	if name, ok := d["name"].(string); ok {
+		f.Name = name
+	}
+	if t, ok := d["type"].(string); ok && t != "" {
+		f.Type = FieldType(t)
+	}
+	if format, ok := d["format"].(string); ok {
+		f.Format = format
+	}
+	return f
+}
+
+// Cast converts the raw CSV cell value into the Go value matching f.Type.
+func (f Field) Cast(raw string) (interface{}, error) {
+	switch f.Type {
+	case TypeString, "":
+		return raw, nil
+	case TypeNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: invalid number %q: %w", f.Name, raw, err)
+		}
+		return v, nil
+	case TypeInteger:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: invalid integer %q: %w", f.Name, raw, err)
+		}
+		return v, nil
+	case TypeBoolean:
+		switch strings.ToLower(raw) {
+		case "true", "yes", "1":
+			return true, nil
+		case "false", "no", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("field %q: invalid boolean %q", f.Name, raw)
+	case TypeDate:
+		v, err := f.parseTime(raw, defaultDateLayout, dateAnyLayouts)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: invalid date %q: %w", f.Name, raw, err)
+		}
+		return v, nil
+	case TypeDateTime:
+		v, err := f.parseTime(raw, defaultDateTimeLayout, dateTimeAnyLayouts)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: invalid datetime %q: %w", f.Name, raw, err)
+		}
+		return v, nil
+	case TypeArray:
+		var v []interface{}
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, fmt.Errorf("field %q: invalid array %q: %w", f.Name, raw, err)
+		}
+		return v, nil
+	case TypeObject:
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, fmt.Errorf("field %q: invalid object %q: %w", f.Name, raw, err)
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
+}
+
+// dateAnyLayouts and dateTimeAnyLayouts are the reference layouts tried, in
+// order, when a field's "format" is "any". The Table Schema spec leaves
+// "any" parsing strategy up to the implementation; these cover the ISO 8601
+// variants and the spec's own "default" layouts.
+var (
+	dateAnyLayouts = []string{
+		defaultDateLayout,
+		"2006-01-02T15:04:05Z07:00",
+		"01/02/2006",
+		"2006/01/02",
+	}
+	dateTimeAnyLayouts = []string{
+		defaultDateTimeLayout,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		defaultDateLayout,
+	}
+)
+
+// parseTime parses raw according to f.Format: "default" (or unset) uses def,
+// "any" tries each of anyLayouts in turn, and anything else is taken as an
+// explicit Go reference-time layout.
+func (f Field) parseTime(raw, def string, anyLayouts []string) (time.Time, error) {
+	switch f.Format {
+	case "", "default":
+		return time.Parse(def, raw)
+	case "any":
+		var err error
+		for _, layout := range anyLayouts {
+			var v time.Time
+			v, err = time.Parse(layout, raw)
+			if err == nil {
+				return v, nil
+			}
+		}
+		return time.Time{}, err
+	default:
+		return time.Parse(f.Format, raw)
+	}
+}
@@ -0,0 +1,72 @@
+package tableschema
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RowIterator streams rows out of a tabular resource, casting each cell
+// according to a Schema.
+type RowIterator struct {
+	csv    *csv.Reader
+	schema Schema
+	header []string
+	closer io.Closer
+}
+
+// NewRowIterator builds a RowIterator over r, configuring the CSV parser
+// from dialect and consuming a header row up front when dialect.Header is
+// set.
+//
+// Go's encoding/csv has no configurable quote character, so a dialect
+// asking for anything other than the default '"' is rejected rather than
+// silently parsed as if it weren't there.
+func NewRowIterator(r io.Reader, schema Schema, dialect Dialect) (*RowIterator, error) {
+	if dialect.QuoteChar != 0 && dialect.QuoteChar != DefaultDialect.QuoteChar {
+		return nil, fmt.Errorf("tableschema: unsupported quoteChar %q: encoding/csv only supports %q", dialect.QuoteChar, DefaultDialect.QuoteChar)
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = dialect.Delimiter
+	cr.TrimLeadingSpace = dialect.SkipInitialSpace
+	cr.FieldsPerRecord = -1
+
+	it := &RowIterator{csv: cr, schema: schema}
+	if closer, ok := r.(io.Closer); ok {
+		it.closer = closer
+	}
+	if dialect.Header {
+		header, err := cr.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		it.header = header
+	}
+	return it, nil
+}
+
+// Header returns the header row consumed at construction time, or nil if
+// the dialect has no header.
+func (it *RowIterator) Header() []string {
+	return it.header
+}
+
+// Next reads and casts the next row. It returns io.EOF once rows are
+// exhausted.
+func (it *RowIterator) Next() ([]interface{}, error) {
+	row, err := it.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+	return it.schema.CastRow(row)
+}
+
+// Close releases the underlying reader, if it is closeable.
+func (it *RowIterator) Close() error {
+	if it.closer != nil {
+		return it.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package tableschema
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRowIterator(t *testing.T) {
+	is := is.New(t)
+	schema, err := FromDescriptor(map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"name": "id", "type": "integer"},
+			map[string]interface{}{"name": "name", "type": "string"},
+			map[string]interface{}{"name": "active", "type": "boolean"},
+		},
+	})
+	is.NoErr(err)
+
+	it, err := NewRowIterator(strings.NewReader("id,name,active\n1,Alice,true\n2,Bob,false\n"), schema, DefaultDialect)
+	is.NoErr(err)
+	is.Equal(it.Header(), []string{"id", "name", "active"})
+
+	row, err := it.Next()
+	is.NoErr(err)
+	is.Equal(row, []interface{}{int64(1), "Alice", true})
+
+	row, err = it.Next()
+	is.NoErr(err)
+	is.Equal(row, []interface{}{int64(2), "Bob", false})
+
+	_, err = it.Next()
+	is.True(err == io.EOF)
+}
+
+func TestRowIterator_UnsupportedQuoteChar(t *testing.T) {
+	is := is.New(t)
+	schema, err := FromDescriptor(map[string]interface{}{
+		"fields": []interface{}{map[string]interface{}{"name": "id", "type": "integer"}},
+	})
+	is.NoErr(err)
+
+	dialect := DefaultDialect
+	dialect.QuoteChar = '\''
+	_, err = NewRowIterator(strings.NewReader("id\n'1'\n"), schema, dialect)
+	is.True(err != nil)
+}
+
+func TestFieldCast(t *testing.T) {
+	is := is.New(t)
+	f := Field{Name: "n", Type: TypeNumber}
+	v, err := f.Cast("3.14")
+	is.NoErr(err)
+	is.Equal(v, 3.14)
+
+	_, err = f.Cast("not-a-number")
+	is.True(err != nil)
+}
+
+func TestFieldCast_DateFormatAny(t *testing.T) {
+	is := is.New(t)
+	f := Field{Name: "d", Type: TypeDate, Format: "any"}
+
+	v, err := f.Cast("2020-01-02")
+	is.NoErr(err)
+	is.Equal(v.(time.Time).Format(defaultDateLayout), "2020-01-02")
+
+	v, err = f.Cast("01/02/2020")
+	is.NoErr(err)
+	is.Equal(v.(time.Time).Format(defaultDateLayout), "2020-01-02")
+
+	_, err = f.Cast("not a date")
+	is.True(err != nil)
+}
+
+func TestFieldCast_DateTimeFormatAny(t *testing.T) {
+	is := is.New(t)
+	f := Field{Name: "dt", Type: TypeDateTime, Format: "any"}
+
+	v, err := f.Cast("2020-01-02T15:04:05Z")
+	is.NoErr(err)
+	is.Equal(v.(time.Time).Format(time.RFC3339), "2020-01-02T15:04:05Z")
+
+	v, err = f.Cast("2020-01-02 15:04:05")
+	is.NoErr(err)
+	is.Equal(v.(time.Time).UTC().Format("2006-01-02T15:04:05"), "2020-01-02T15:04:05")
+
+	_, err = f.Cast("not a datetime")
+	is.True(err != nil)
+}
+
+func TestFieldCast_DateExplicitLayout(t *testing.T) {
+	is := is.New(t)
+	f := Field{Name: "d", Type: TypeDate, Format: "02-01-2006"}
+
+	v, err := f.Cast("31-12-2020")
+	is.NoErr(err)
+	is.Equal(v.(time.Time).Format(defaultDateLayout), "2020-12-31")
+}
@@ -0,0 +1,53 @@
+package tableschema
+
+import "fmt"
+
+// Schema is a parsed Table Schema "schema" descriptor.
+type Schema struct {
+	Fields []Field
+}
+
+// FromDescriptor builds a Schema from its JSON descriptor.
+func FromDescriptor(d map[string]interface{}) (Schema, error) {
+	raw, ok := d["fields"].([]interface{})
+	if !ok || len(raw) == 0 {
+		return Schema{}, fmt.Errorf(`schema must contain a non-empty "fields" property`)
+	}
+	fields := make([]Field, len(raw))
+	for i, rf := range raw {
+		fd, ok := rf.(map[string]interface{})
+		if !ok {
+			return Schema{}, fmt.Errorf("field at index %d is not a valid descriptor", i)
+		}
+		fields[i] = FieldFromDescriptor(fd)
+	}
+	return Schema{Fields: fields}, nil
+}
+
+// FieldNames returns the name of every Field, in schema order.
+func (s Schema) FieldNames() []string {
+	names := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// CastRow casts every cell of row according to the matching Field, by
+// position. Cells beyond the last declared Field are passed through
+// unchanged, matching the Table Schema spec's leniency for ragged rows.
+func (s Schema) CastRow(row []string) ([]interface{}, error) {
+	out := make([]interface{}, len(row))
+	for i, raw := range row {
+		if i >= len(s.Fields) {
+			out[i] = raw
+			continue
+		}
+		v, err := s.Fields[i].Cast(raw)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
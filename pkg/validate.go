@@ -0,0 +1,119 @@
+package pkg
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Profile identifies which Frictionless Data JSON Schema a descriptor should
+// be validated against.
+type Profile string
+
+// Profiles supported by Validate, one per embedded schema in pkg/schemas.
+const (
+	ProfileDataPackage        Profile = "data-package"
+	ProfileTabularDataPackage Profile = "tabular-data-package"
+	ProfileFiscalDataPackage  Profile = "fiscal-data-package"
+	ProfileDataResource       Profile = "data-resource"
+)
+
+// ValidateOption configures a call to Validate.
+type ValidateOption func(*validateConfig)
+
+type validateConfig struct {
+	profile Profile
+}
+
+// WithProfile selects the profile a descriptor is validated against.
+// Validate defaults to ProfileDataPackage when no WithProfile option is
+// given.
+func WithProfile(p Profile) ValidateOption {
+	return func(c *validateConfig) { c.profile = p }
+}
+
+// ValidationError describes a single JSON Schema validation failure.
+type ValidationError struct {
+	// Path is the dot-separated location of the failing value, e.g.
+	// "resources.0.name".
+	Path string
+	// Keyword is the JSON Schema keyword that was violated, e.g. "required".
+	Keyword string
+	// Message is a human-readable description of the failure.
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", e.Path, e.Message, e.Keyword)
+}
+
+// ValidationErrors collects every ValidationError found in a single
+// Validate call.
+type ValidationErrors []ValidationError
+
+func (es ValidationErrors) Error() string {
+	if len(es) == 1 {
+		return es[0].Error()
+	}
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(es), strings.Join(msgs, "; "))
+}
+
+var (
+	schemaCacheMu sync.Mutex
+	schemaCache   = map[Profile]*gojsonschema.Schema{}
+)
+
+func loadSchema(p Profile) (*gojsonschema.Schema, error) {
+	schemaCacheMu.Lock()
+	defer schemaCacheMu.Unlock()
+
+	if s, ok := schemaCache[p]; ok {
+		return s, nil
+	}
+	buf, err := schemaFS.ReadFile(fmt.Sprintf("schemas/%s.json", p))
+	if err != nil {
+		return nil, fmt.Errorf("unknown profile %q: %w", p, err)
+	}
+	s, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(buf))
+	if err != nil {
+		return nil, fmt.Errorf("failed compiling schema for profile %q: %w", p, err)
+	}
+	schemaCache[p] = s
+	return s, nil
+}
+
+// Validate checks desc against the Frictionless Data profile selected via
+// opts (ProfileDataPackage by default), returning a ValidationErrors with
+// one entry per schema violation, or nil if desc is valid.
+func Validate(desc map[string]interface{}, opts ...ValidateOption) error {
+	cfg := validateConfig{profile: ProfileDataPackage}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	schema, err := loadSchema(cfg.profile)
+	if err != nil {
+		return err
+	}
+	result, err := schema.Validate(gojsonschema.NewGoLoader(desc))
+	if err != nil {
+		return fmt.Errorf("failed validating descriptor: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	errs := make(ValidationErrors, len(result.Errors()))
+	for i, re := range result.Errors() {
+		errs[i] = ValidationError{Path: re.Field(), Keyword: re.Type(), Message: re.Description()}
+	}
+	return errs
+}
@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("ValidDataPackage", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{"name": "res", "path": "data.csv"},
+			},
+		})
+		is.NoErr(err)
+	})
+	t.Run("MissingResources", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{})
+		is.True(err != nil)
+		verrs, ok := err.(ValidationErrors)
+		is.True(ok)
+		is.True(len(verrs) > 0)
+	})
+	t.Run("TabularProfileRequiresSchema", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{"name": "res", "path": "data.csv"},
+			},
+		}, WithProfile(ProfileTabularDataPackage))
+		is.True(err != nil)
+	})
+	t.Run("UnknownProfile", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{}, WithProfile(Profile("does-not-exist")))
+		is.True(err != nil)
+	})
+	t.Run("ConcurrentLoadSchema", func(t *testing.T) {
+		is := is.New(t)
+		desc := map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{"name": "res", "path": "data.csv"},
+			},
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = Validate(desc, WithProfile(ProfileDataPackage))
+			}()
+		}
+		wg.Wait()
+		is.True(true)
+	})
+}
+
+func TestNewResource(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "res", "path": "data.csv"})
+		is.NoErr(err)
+		is.Equal(r.Name, "res")
+	})
+	t.Run("MissingName", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewResource(map[string]interface{}{"path": "data.csv"})
+		is.True(err != nil)
+	})
+}
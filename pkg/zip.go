@@ -0,0 +1,299 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// packageDescriptorName is the well-known root entry of a "data package as
+// zip" archive, per the Frictionless Data spec.
+const packageDescriptorName = "datapackage.json"
+
+// LoadZip reads a standard "data package as zip" archive from path: a
+// datapackage.json at the root plus each resource's "path" files bundled
+// alongside. Resource paths are resolved inside the archive, so the
+// existing Resource accessors (Iter/ReadAll/Cast) keep working unchanged.
+// Resource "hash"/"bytes" properties, when present, are verified against
+// the archived file.
+func LoadZip(path string, factory resourceFactory) (*Package, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening zip package %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	buf, err := readZipFile(&zr.Reader, packageDescriptorName)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading %s from %s: %w", packageDescriptorName, path, err)
+	}
+	var d map[string]interface{}
+	if err := json.Unmarshal(buf, &d); err != nil {
+		return nil, fmt.Errorf("failed decoding %s: %w", packageDescriptorName, err)
+	}
+
+	p, err := fromDescriptor(d, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyZipIntegrity(&zr.Reader, p.resources); err != nil {
+		return nil, err
+	}
+
+	archive, err := newMemFS(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range p.resources {
+		r.fsys = archive
+	}
+	return p, nil
+}
+
+// SaveZipOption configures a call to (*Package).SaveZip.
+type SaveZipOption func(*saveZipConfig)
+
+type saveZipConfig struct {
+	fetchRemote bool
+	client      *http.Client
+}
+
+// WithFetchRemote controls whether SaveZip fetches remote-URL resources and
+// inlines them into the archive. It defaults to false, leaving remote
+// resources' descriptor "path" untouched and the data unbundled.
+func WithFetchRemote(fetch bool) SaveZipOption {
+	return func(c *saveZipConfig) { c.fetchRemote = fetch }
+}
+
+// WithZipHTTPClient overrides the http.Client used to fetch remote
+// resources when WithFetchRemote(true) is set.
+func WithZipHTTPClient(c *http.Client) SaveZipOption {
+	return func(cfg *saveZipConfig) { cfg.client = c }
+}
+
+// SaveZip writes the Package as a standard "data package as zip" archive: a
+// datapackage.json at the root plus each resource's "path" files bundled
+// alongside. Resources with inline "data" are materialized to files in the
+// archive; local-file resources are bundled as-is; remote-URL resources are
+// left untouched unless WithFetchRemote(true) is given, in which case they
+// are fetched and inlined too. A resource whose "path" is an array of paths
+// is left as it is: its files are not bundled.
+func (p *Package) SaveZip(w io.Writer, opts ...SaveZipOption) (err error) {
+	cfg := saveZipConfig{client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p.mu.RLock()
+	desc, err := deepCopyDescriptor(p.descriptor)
+	resources := p.resources
+	p.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	resourcesRaw, _ := desc["resources"].([]interface{})
+
+	zw := zip.NewWriter(w)
+	defer func() {
+		if cerr := zw.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	for i, r := range resources {
+		rd, ok := resourcesRaw[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := bundleResource(zw, &cfg, r.Name, rd); err != nil {
+			return err
+		}
+	}
+
+	descBuf, err := json.Marshal(desc)
+	if err != nil {
+		return fmt.Errorf("failed encoding %s: %w", packageDescriptorName, err)
+	}
+	return writeZipFile(zw, packageDescriptorName, descBuf)
+}
+
+// bundleResource materializes rd's data into the archive, rewriting its
+// "path"/"data" properties in place to reflect where it landed.
+func bundleResource(zw *zip.Writer, cfg *saveZipConfig, name string, rd map[string]interface{}) error {
+	if data, ok := rd["data"]; ok {
+		s, ok := data.(string)
+		if !ok {
+			return fmt.Errorf("resource %q: inline non-string \"data\" is not supported by SaveZip", name)
+		}
+		entry := zipEntryName(name)
+		if err := writeZipFile(zw, entry, []byte(s)); err != nil {
+			return err
+		}
+		delete(rd, "data")
+		rd["path"] = entry
+		return nil
+	}
+
+	p, _ := rd["path"].(string)
+	if p == "" {
+		return nil
+	}
+	if strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") {
+		if !cfg.fetchRemote {
+			return nil
+		}
+		resp, err := cfg.client.Get(p)
+		if err != nil {
+			return fmt.Errorf("resource %q: failed fetching %s: %w", name, p, err)
+		}
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("resource %q: failed reading %s: %w", name, p, err)
+		}
+		entry := zipEntryName(name)
+		if err := writeZipFile(zw, entry, content); err != nil {
+			return err
+		}
+		rd["path"] = entry
+		return nil
+	}
+
+	if err := safeLocalPath(p); err != nil {
+		return fmt.Errorf("resource %q: %w", name, err)
+	}
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("resource %q: failed reading %s: %w", name, p, err)
+	}
+	return writeZipFile(zw, p, content)
+}
+
+func zipEntryName(resourceName string) string {
+	return resourceName + ".csv"
+}
+
+func writeZipFile(zw *zip.Writer, name string, content []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed creating %s in archive: %w", name, err)
+	}
+	_, err = f.Write(content)
+	return err
+}
+
+func readZipFile(zr *zip.Reader, name string) ([]byte, error) {
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// verifyZipIntegrity checks every resource's "bytes"/"hash" properties, when
+// present, against the matching archived file.
+func verifyZipIntegrity(zr *zip.Reader, resources []*Resource) error {
+	for _, r := range resources {
+		p := r.path()
+		if p == "" {
+			continue
+		}
+		wantBytes, hasBytes := r.descriptor["bytes"]
+		wantHash, hasHash := r.descriptor["hash"].(string)
+		if !hasBytes && (!hasHash || wantHash == "") {
+			continue
+		}
+		data, err := readZipFile(zr, p)
+		if err != nil {
+			return fmt.Errorf("resource %q: failed reading %s for integrity check: %w", r.Name, p, err)
+		}
+		if hasBytes {
+			if n, ok := toInt64(wantBytes); ok && n != int64(len(data)) {
+				return fmt.Errorf("resource %q: size mismatch: descriptor says %d bytes, archive has %d", r.Name, n, len(data))
+			}
+		}
+		if hasHash && wantHash != "" {
+			sum := md5.Sum(data)
+			if got := hex.EncodeToString(sum[:]); got != wantHash {
+				return fmt.Errorf("resource %q: hash mismatch: descriptor says %s, archive has %s", r.Name, wantHash, got)
+			}
+		}
+	}
+	return nil
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// memFS is a minimal, read-only, in-memory fs.FS built by reading an
+// archive's contents up front, so the archive's backing file can be closed
+// as soon as LoadZip returns.
+type memFS struct {
+	files map[string][]byte
+}
+
+func newMemFS(zr *zip.Reader) (*memFS, error) {
+	files := make(map[string][]byte, len(zr.File))
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		data, err := readZipFile(zr, zf.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading %s from archive: %w", zf.Name, err)
+		}
+		files[zf.Name] = data
+	}
+	return &memFS{files: files}, nil
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	data, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFile{name: name, Reader: bytes.NewReader(data), size: int64(len(data))}, nil
+}
+
+type memFile struct {
+	name string
+	*bytes.Reader
+	size int64
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return memFileInfo{name: f.name, size: f.size}, nil }
+func (f *memFile) Close() error               { return nil }
+
+type memFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi memFileInfo) Name() string       { return path.Base(fi.name) }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() fs.FileMode  { return 0 }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return false }
+func (fi memFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,90 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadZip(t *testing.T) {
+	is := is.New(t)
+	buf := buildZip(t, map[string]string{
+		"datapackage.json": `{"resources":[{"name":"res","path":"data/res.csv","schema":{"fields":[{"name":"id","type":"integer"},{"name":"name","type":"string"}]}}]}`,
+		"data/res.csv":     "id,name\n1,Alice\n2,Bob\n",
+	})
+	dir := t.TempDir()
+	path := dir + "/pkg.zip"
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadZip(path, NewUncheckedResource)
+	is.NoErr(err)
+
+	r := p.GetResource("res")
+	is.True(r != nil)
+	rows, err := r.ReadAll()
+	is.NoErr(err)
+	is.Equal(rows, [][]interface{}{{int64(1), "Alice"}, {int64(2), "Bob"}})
+}
+
+func TestLoadZip_IntegrityMismatch(t *testing.T) {
+	is := is.New(t)
+	buf := buildZip(t, map[string]string{
+		"datapackage.json": `{"resources":[{"name":"res","path":"res.csv","bytes":999}]}`,
+		"res.csv":          "id\n1\n",
+	})
+	dir := t.TempDir()
+	path := dir + "/pkg.zip"
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadZip(path, NewUncheckedResource)
+	is.True(err != nil)
+}
+
+func TestPackage_SaveZip(t *testing.T) {
+	is := is.New(t)
+	p, err := fromDescriptor(map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{"name": "res", "data": "id\n1\n2\n"},
+		},
+	}, NewUncheckedResource)
+	is.NoErr(err)
+
+	var out bytes.Buffer
+	is.NoErr(p.SaveZip(&out))
+
+	zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	is.NoErr(err)
+
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	is.True(names["datapackage.json"])
+	is.True(names["res.csv"])
+}